@@ -0,0 +1,34 @@
+// Command qaapi runs the question & answer HTTP API.
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/axel9119/qaapi/internal/store"
+	"github.com/axel9119/qaapi/internal/transport"
+)
+
+func main() {
+	dsn := os.Getenv("DATABASE_DSN")
+	if dsn == "" {
+		dsn = "host=localhost user=qa password=qa_pass dbname=qa_db port=5432 sslmode=disable"
+	}
+
+	db, err := store.Connect(dsn)
+	if err != nil {
+		log.Fatalf("DB connect error: %v", err)
+	}
+
+	specPath := os.Getenv("OPENAPI_SPEC_PATH")
+	if specPath == "" {
+		specPath = "api/openapi.yaml"
+	}
+
+	api := transport.New(store.New(db))
+	handler := transport.NewRouter(api, specPath)
+
+	log.Println("server started on :8080")
+	log.Fatal(http.ListenAndServe(":8080", handler))
+}
@@ -0,0 +1,335 @@
+// Package store owns the qaapi data model and all persistence logic: every
+// query the transport layer needs goes through a *Store method so handlers
+// never touch *gorm.DB directly.
+package store
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// pgUniqueViolation is the Postgres error code for a unique-constraint
+// violation. See https://www.postgresql.org/docs/current/errcodes-appendix.html
+const pgUniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err is a Postgres unique-constraint
+// violation, as opposed to a transient failure (connection drop, timeout)
+// that happens to occur on the same query.
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
+
+/* ============================
+   Models
+   ============================ */
+
+type User struct {
+	ID        int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Email     string    `gorm:"type:text;not null;unique" json:"email"`
+	TokenHash string    `gorm:"type:text;not null;index" json:"-"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type Question struct {
+	ID        int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Text      string    `gorm:"type:text;not null" json:"text"`
+	AuthorID  int       `gorm:"not null;index" json:"author_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Answers   []Answer  `gorm:"constraint:OnDelete:CASCADE" json:"answers,omitempty"`
+}
+
+type Answer struct {
+	ID         int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	QuestionID int       `gorm:"not null;index" json:"question_id"`
+	UserID     int       `gorm:"not null;index" json:"user_id"`
+	Text       string    `gorm:"type:text;not null" json:"text"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type Vote struct {
+	ID       int `gorm:"primaryKey;autoIncrement" json:"id"`
+	AnswerID int `gorm:"not null;uniqueIndex:idx_votes_answer_user" json:"answer_id"`
+	UserID   int `gorm:"not null;uniqueIndex:idx_votes_answer_user" json:"user_id"`
+	Value    int `gorm:"not null" json:"value"`
+}
+
+// AnswerWithScore is the shape of an answer once its votes have been
+// aggregated; it is what question detail responses embed instead of a
+// bare Answer so clients can sort/rank without a second round trip.
+type AnswerWithScore struct {
+	Answer
+	Score int `json:"score"`
+}
+
+// QuestionDetail is a question plus its answers ranked by the requested sort.
+type QuestionDetail struct {
+	Question
+	// Answers shadows the embedded Question.Answers for JSON purposes: both
+	// carry the "answers" tag, and encoding/json prefers the shallower field.
+	Answers []AnswerWithScore `json:"answers"`
+}
+
+/* ============================
+   Store
+   ============================ */
+
+// Store is the repository layer: one instance wraps a *gorm.DB and exposes
+// every query the transport layer needs as a method.
+type Store struct {
+	db *gorm.DB
+}
+
+// New wraps an already-connected *gorm.DB in a Store.
+func New(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Connect opens the database connection described by dsn and migrates the
+// schema, returning a ready-to-use *gorm.DB.
+func Connect(dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.AutoMigrate(&User{}, &Question{}, &Answer{}, &Vote{}); err != nil {
+		return nil, err
+	}
+
+	// AutoMigrate has no concept of expression indexes, so the full-text
+	// search index backing QuestionFilter.Q is created by hand here.
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_questions_text_fts
+		ON questions USING GIN (to_tsvector('simple', text))`).Error; err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+/* ============================
+   Auth
+   ============================ */
+
+// NewToken returns a bearer token for the client and the hash to persist.
+// The raw token is only ever returned once, at registration time; only its
+// sha256 hash is stored so a leaked DB dump can't be replayed as tokens.
+func NewToken() (raw string, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	raw = hex.EncodeToString(buf)
+	return raw, HashToken(raw), nil
+}
+
+func HashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Store) UserByToken(raw string) (*User, error) {
+	var u User
+	err := s.db.Where("token_hash = ?", HashToken(raw)).First(&u).Error
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+/* ============================
+   Users
+   ============================ */
+
+func (s *Store) CreateUser(u *User) error {
+	return s.db.Create(u).Error
+}
+
+/* ============================
+   Questions
+   ============================ */
+
+func (s *Store) CreateQuestion(q *Question) error {
+	return s.db.Create(q).Error
+}
+
+// defaultQuestionsLimit is the page size used when QuestionFilter.Limit is
+// left at its zero value.
+const defaultQuestionsLimit = 20
+
+// QuestionFilter narrows a question listing. It is a plain struct rather
+// than positional arguments so future callers (CLI, gRPC) can build and
+// reuse one without caring which fields the HTTP layer happened to expose.
+type QuestionFilter struct {
+	Limit  int
+	Offset int
+	Q      string
+	Since  *time.Time
+}
+
+// QuestionPage is a page of questions plus enough bookkeeping for a client
+// to fetch the next page and know when it has reached the end.
+type QuestionPage struct {
+	Items      []Question `json:"items"`
+	NextOffset *int       `json:"next_offset"`
+	Total      int64      `json:"total"`
+}
+
+// GetAllQuestions lists questions matching f, newest-id-last, with limit/
+// offset pagination. f.Q performs a full-text search over questions.text
+// via the tsvector index created in Connect.
+func (s *Store) GetAllQuestions(f QuestionFilter) (*QuestionPage, error) {
+	query := s.db.Model(&Question{})
+
+	if f.Q != "" {
+		query = query.Where("to_tsvector('simple', text) @@ plainto_tsquery('simple', ?)", f.Q)
+	}
+	if f.Since != nil {
+		query = query.Where("created_at > ?", *f.Since)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultQuestionsLimit
+	}
+	offset := f.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	var qs []Question
+	if err := query.Order("id").Limit(limit).Offset(offset).Find(&qs).Error; err != nil {
+		return nil, err
+	}
+
+	var nextOffset *int
+	if next := offset + len(qs); int64(next) < total {
+		nextOffset = &next
+	}
+
+	return &QuestionPage{Items: qs, NextOffset: nextOffset, Total: total}, nil
+}
+
+// GetQuestion loads a single question by ID, with no answers attached.
+func (s *Store) GetQuestion(id int) (*Question, error) {
+	var q Question
+	if err := s.db.First(&q, id).Error; err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+// GetQuestionWithAnswers loads a question together with its answers ranked
+// by the given sort ("score", the default, or "created_at"). Scores are
+// computed in a single aggregation query rather than in Go so ranking stays
+// correct under concurrent voting.
+func (s *Store) GetQuestionWithAnswers(id int, sort string) (*QuestionDetail, error) {
+	var q Question
+	if err := s.db.First(&q, id).Error; err != nil {
+		return nil, err
+	}
+
+	query := s.db.Table("answers").
+		Select("answers.*, COALESCE(SUM(votes.value), 0) AS score").
+		Joins("LEFT JOIN votes ON votes.answer_id = answers.id").
+		Where("answers.question_id = ?", id).
+		Group("answers.id")
+
+	switch sort {
+	case "created_at":
+		query = query.Order("answers.created_at ASC")
+	default:
+		query = query.Order("score DESC")
+	}
+
+	var answers []AnswerWithScore
+	if err := query.Scan(&answers).Error; err != nil {
+		return nil, err
+	}
+
+	return &QuestionDetail{Question: q, Answers: answers}, nil
+}
+
+func (s *Store) DeleteQuestion(id, authorID int) error {
+	res := s.db.Where("author_id = ?", authorID).Delete(&Question{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+/* ============================
+   Answers
+   ============================ */
+
+func (s *Store) CreateAnswer(a *Answer) error {
+	var q Question
+	if err := s.db.First(&q, a.QuestionID).Error; err != nil {
+		return err
+	}
+	return s.db.Create(a).Error
+}
+
+func (s *Store) GetAnswer(id int) (*Answer, error) {
+	var a Answer
+	if err := s.db.First(&a, id).Error; err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *Store) DeleteAnswer(id, userID int) error {
+	res := s.db.Where("user_id = ?", userID).Delete(&Answer{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+/* ============================
+   Votes
+   ============================ */
+
+// VoteAnswer records a user's vote on an answer, or changes its value if
+// the user already voted (the unique index on answer_id+user_id is what
+// keeps a single session/token from voting twice).
+func (s *Store) VoteAnswer(v *Vote) error {
+	var a Answer
+	if err := s.db.First(&a, v.AnswerID).Error; err != nil {
+		return err
+	}
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "answer_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value"}),
+	}).Create(v).Error
+}
+
+func (s *Store) RemoveVote(answerID, userID int) error {
+	res := s.db.Where("answer_id = ? AND user_id = ?", answerID, userID).Delete(&Vote{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// newTestStore connects to the Postgres instance named by DATABASE_DSN (or
+// the same default main.go uses) and wipes its tables. Tests skip, rather
+// than fail, when no database is reachable.
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	dsn := os.Getenv("DATABASE_DSN")
+	if dsn == "" {
+		dsn = "host=localhost user=qa password=qa_pass dbname=qa_db port=5432 sslmode=disable"
+	}
+
+	db, err := Connect(dsn)
+	if err != nil {
+		t.Skipf("postgres not reachable at %q: %v", dsn, err)
+	}
+
+	if err := db.Exec("TRUNCATE votes, answers, questions, users RESTART IDENTITY CASCADE").Error; err != nil {
+		t.Fatalf("truncate tables: %v", err)
+	}
+
+	return New(db)
+}
+
+func TestGetAllQuestionsPaginationTotalAndSearch(t *testing.T) {
+	s := newTestStore(t)
+
+	author := User{Email: "seed@example.com", TokenHash: "seed-hash"}
+	if err := s.CreateUser(&author); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	const total = 50
+	for i := 0; i < total; i++ {
+		text := fmt.Sprintf("question number %d", i)
+		if i == 25 {
+			text = "a question about golang channels"
+		}
+		q := Question{Text: text, AuthorID: author.ID}
+		if err := s.CreateQuestion(&q); err != nil {
+			t.Fatalf("CreateQuestion %d: %v", i, err)
+		}
+	}
+
+	t.Run("pagination and ordering", func(t *testing.T) {
+		page, err := s.GetAllQuestions(QuestionFilter{Limit: 10})
+		if err != nil {
+			t.Fatalf("GetAllQuestions: %v", err)
+		}
+		if page.Total != total {
+			t.Fatalf("total = %d, want %d", page.Total, total)
+		}
+		if len(page.Items) != 10 {
+			t.Fatalf("len(items) = %d, want 10", len(page.Items))
+		}
+		if page.NextOffset == nil || *page.NextOffset != 10 {
+			t.Fatalf("next_offset = %v, want 10", page.NextOffset)
+		}
+		for i := 1; i < len(page.Items); i++ {
+			if page.Items[i].ID <= page.Items[i-1].ID {
+				t.Fatalf("items not ordered by id ascending: %d then %d", page.Items[i-1].ID, page.Items[i].ID)
+			}
+		}
+
+		last, err := s.GetAllQuestions(QuestionFilter{Limit: 10, Offset: total - 10})
+		if err != nil {
+			t.Fatalf("GetAllQuestions last page: %v", err)
+		}
+		if last.NextOffset != nil {
+			t.Fatalf("next_offset = %v on the last page, want nil", last.NextOffset)
+		}
+	})
+
+	t.Run("full-text search", func(t *testing.T) {
+		page, err := s.GetAllQuestions(QuestionFilter{Q: "golang channels"})
+		if err != nil {
+			t.Fatalf("GetAllQuestions with q: %v", err)
+		}
+		if page.Total != 1 {
+			t.Fatalf("total = %d, want 1 matching question", page.Total)
+		}
+		if len(page.Items) != 1 || page.Items[0].Text != "a question about golang channels" {
+			t.Fatalf("unexpected search results: %+v", page.Items)
+		}
+	})
+}
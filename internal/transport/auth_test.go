@@ -0,0 +1,58 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/axel9119/qaapi/internal/store"
+)
+
+func TestAuthFlowEndToEnd(t *testing.T) {
+	api := newTestAPI(t)
+	ts := httptest.NewServer(NewRouter(api, ""))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/questions", "application/json", bytes.NewReader([]byte(`{"text":"no token"}`)))
+	if err != nil {
+		t.Fatalf("POST /questions: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 creating a question without a token, got %d", resp.StatusCode)
+	}
+
+	aliceToken := registerUser(t, ts.URL, "alice@example.com")
+	bobToken := registerUser(t, ts.URL, "bob@example.com")
+
+	resp = authedRequest(t, http.MethodPost, ts.URL+"/questions", aliceToken, []byte(`{"text":"what is go"}`))
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /questions as alice: status %d", resp.StatusCode)
+	}
+
+	var q store.Question
+	if err := json.NewDecoder(resp.Body).Decode(&q); err != nil {
+		t.Fatalf("decode question: %v", err)
+	}
+	if q.AuthorID == 0 {
+		t.Fatalf("expected question.author_id to be populated from the token, got 0")
+	}
+
+	questionURL := fmt.Sprintf("%s/questions/%d", ts.URL, q.ID)
+
+	resp = authedRequest(t, http.MethodDelete, questionURL, bobToken, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 deleting another user's question, got %d", resp.StatusCode)
+	}
+
+	resp = authedRequest(t, http.MethodDelete, questionURL, aliceToken, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204 deleting the owner's own question, got %d", resp.StatusCode)
+	}
+}
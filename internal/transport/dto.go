@@ -0,0 +1,12 @@
+package transport
+
+import "github.com/axel9119/qaapi/internal/store"
+
+// CreateUserResponse is the one hand-maintained DTO: it embeds store.User
+// plus a Token field that only exists at registration time, which doesn't
+// fit the generated request types in types.gen.go. Keep it in sync with the
+// CreateUserResponse schema in api/openapi.yaml by hand.
+type CreateUserResponse struct {
+	store.User
+	Token string `json:"token"`
+}
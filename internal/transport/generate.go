@@ -0,0 +1,10 @@
+package transport
+
+// Request DTOs are generated from api/openapi.yaml into types.gen.go by
+// oapi-codegen, invoked via `go run` at a pinned version so the generator's
+// own (heavy, fast-moving) dependency tree never enters this module's
+// go.mod/go.sum. Response bodies are, for the most part, store models
+// encoded directly; CreateUserResponse in dto.go is the one hand-written
+// exception, since it embeds store.User with a token field that only
+// exists at registration time.
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen@v1.16.3 -config oapi-codegen-config.yaml -exclude-schemas Answer,AnswerWithScore,Question,QuestionDetail,QuestionPage,Vote,CreateUserResponse ../../api/openapi.yaml
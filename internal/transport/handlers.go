@@ -0,0 +1,272 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/axel9119/qaapi/internal/store"
+	"github.com/julienschmidt/httprouter"
+)
+
+// streamMaxIdle is how long a GET .../answers/stream connection may go
+// without a new answer before the server closes it.
+const streamMaxIdle = 5 * time.Minute
+
+// API holds the dependencies every handler needs.
+type API struct {
+	store *store.Store
+	hub   *hub
+}
+
+// New builds the set of HTTP handlers backed by s.
+func New(s *store.Store) *API {
+	return &API{store: s, hub: newHub()}
+}
+
+func idParam(ps httprouter.Params) (int, error) {
+	return strconv.Atoi(ps.ByName("id"))
+}
+
+func (a *API) createUser(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	var req CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return nil
+	}
+
+	raw, hash, err := store.NewToken()
+	if err != nil {
+		return err
+	}
+
+	u := store.User{Email: req.Email, TokenHash: hash}
+	if err := a.store.CreateUser(&u); err != nil {
+		if store.IsUniqueViolation(err) {
+			http.Error(w, "email already registered", http.StatusConflict)
+			return nil
+		}
+		return err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(CreateUserResponse{User: u, Token: raw})
+}
+
+func (a *API) createQuestion(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	var req CreateQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return nil
+	}
+
+	q := store.Question{Text: req.Text, AuthorID: userFromRequest(r).ID}
+	if err := a.store.CreateQuestion(&q); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(q)
+}
+
+func (a *API) listQuestions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	q := r.URL.Query()
+
+	f := store.QuestionFilter{Q: q.Get("q")}
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			f.Limit = n
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			f.Offset = n
+		}
+	}
+	if v := q.Get("since"); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			f.Since = &t
+		}
+	}
+
+	page, err := a.store.GetAllQuestions(f)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(page)
+}
+
+func (a *API) getQuestion(w http.ResponseWriter, r *http.Request, ps httprouter.Params) error {
+	id, err := idParam(ps)
+	if err != nil {
+		http.Error(w, "bad id", http.StatusBadRequest)
+		return nil
+	}
+
+	q, err := a.store.GetQuestionWithAnswers(id, r.URL.Query().Get("sort"))
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(w).Encode(q)
+}
+
+func (a *API) deleteQuestion(w http.ResponseWriter, r *http.Request, ps httprouter.Params) error {
+	id, err := idParam(ps)
+	if err != nil {
+		http.Error(w, "bad id", http.StatusBadRequest)
+		return nil
+	}
+
+	if err := a.store.DeleteQuestion(id, userFromRequest(r).ID); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (a *API) createAnswer(w http.ResponseWriter, r *http.Request, ps httprouter.Params) error {
+	id, err := idParam(ps)
+	if err != nil {
+		http.Error(w, "bad id", http.StatusBadRequest)
+		return nil
+	}
+
+	var req CreateAnswerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return nil
+	}
+
+	ans := store.Answer{QuestionID: id, UserID: userFromRequest(r).ID, Text: req.Text}
+	if err := a.store.CreateAnswer(&ans); err != nil {
+		return err
+	}
+	a.hub.publish(id, ans)
+
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(ans)
+}
+
+// streamAnswers serves GET /questions/{id}/answers/stream: a Server-Sent
+// Events feed of answers posted to the question after the client connects.
+func (a *API) streamAnswers(w http.ResponseWriter, r *http.Request, ps httprouter.Params) error {
+	id, err := idParam(ps)
+	if err != nil {
+		http.Error(w, "bad id", http.StatusBadRequest)
+		return nil
+	}
+
+	if _, err := a.store.GetQuestion(id); err != nil {
+		return err
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return nil
+	}
+
+	ch, cancel := a.hub.subscribe(id)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	idle := make(chan struct{})
+	timer := time.AfterFunc(streamMaxIdle, func() { close(idle) })
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+		case <-idle:
+			return nil
+		case ans, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			timer.Reset(streamMaxIdle)
+
+			payload, err := json.Marshal(ans)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		}
+	}
+}
+
+func (a *API) getAnswer(w http.ResponseWriter, r *http.Request, ps httprouter.Params) error {
+	id, err := idParam(ps)
+	if err != nil {
+		http.Error(w, "bad id", http.StatusBadRequest)
+		return nil
+	}
+
+	ans, err := a.store.GetAnswer(id)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(w).Encode(ans)
+}
+
+func (a *API) deleteAnswer(w http.ResponseWriter, r *http.Request, ps httprouter.Params) error {
+	id, err := idParam(ps)
+	if err != nil {
+		http.Error(w, "bad id", http.StatusBadRequest)
+		return nil
+	}
+
+	if err := a.store.DeleteAnswer(id, userFromRequest(r).ID); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (a *API) voteAnswer(w http.ResponseWriter, r *http.Request, ps httprouter.Params) error {
+	id, err := idParam(ps)
+	if err != nil {
+		http.Error(w, "bad id", http.StatusBadRequest)
+		return nil
+	}
+
+	var req VoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || (req.Value != 1 && req.Value != -1) {
+		http.Error(w, "value must be 1 or -1", http.StatusBadRequest)
+		return nil
+	}
+
+	v := store.Vote{AnswerID: id, UserID: userFromRequest(r).ID, Value: int(req.Value)}
+	if err := a.store.VoteAnswer(&v); err != nil {
+		return err
+	}
+
+	return json.NewEncoder(w).Encode(v)
+}
+
+func (a *API) removeVote(w http.ResponseWriter, r *http.Request, ps httprouter.Params) error {
+	id, err := idParam(ps)
+	if err != nil {
+		http.Error(w, "bad id", http.StatusBadRequest)
+		return nil
+	}
+
+	if err := a.store.RemoveVote(id, userFromRequest(r).ID); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,62 @@
+package transport
+
+import (
+	"sync"
+
+	"github.com/axel9119/qaapi/internal/store"
+)
+
+// hub fans newly created answers out to GET .../answers/stream subscribers
+// of the owning question. It is process-local: a second qaapi instance
+// behind a load balancer would need its own hub, which is fine for the
+// single-instance deployments this package targets today.
+type hub struct {
+	mu   sync.Mutex
+	subs map[int][]chan store.Answer
+}
+
+func newHub() *hub {
+	return &hub{subs: make(map[int][]chan store.Answer)}
+}
+
+// subscribe registers a buffered channel for questionID's new answers. The
+// returned cancel func must be called exactly once, when the subscriber
+// disconnects, to unregister the channel and stop it leaking.
+func (h *hub) subscribe(questionID int) (ch chan store.Answer, cancel func()) {
+	ch = make(chan store.Answer, 8)
+
+	h.mu.Lock()
+	h.subs[questionID] = append(h.subs[questionID], ch)
+	h.mu.Unlock()
+
+	cancel = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		subs := h.subs[questionID]
+		for i, c := range subs {
+			if c == ch {
+				h.subs[questionID] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}
+
+	return ch, cancel
+}
+
+// publish delivers a to every current subscriber of questionID. A subscriber
+// whose buffer is full misses a rather than blocking the publisher; it stays
+// registered.
+func (h *hub) publish(questionID int, a store.Answer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, ch := range h.subs[questionID] {
+		select {
+		case ch <- a:
+		default:
+		}
+	}
+}
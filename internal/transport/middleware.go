@@ -0,0 +1,159 @@
+package transport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/axel9119/qaapi/internal/store"
+	"github.com/julienschmidt/httprouter"
+	"gorm.io/gorm"
+)
+
+type ctxKey string
+
+const (
+	userCtxKey      ctxKey = "user"
+	requestIDCtxKey ctxKey = "request_id"
+)
+
+// requestID stamps every request with a short random id, exposed to
+// handlers via requestIDFromContext and echoed back as X-Request-Id so a
+// client's report can be matched to a server log line.
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, 8)
+		rand.Read(buf)
+		id := hex.EncodeToString(buf)
+
+		w.Header().Set("X-Request-Id", id)
+		ctx := context.WithValue(r.Context(), requestIDCtxKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey).(string)
+	return id
+}
+
+// logging records method, path, status and latency for every request.
+func logging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(sw, r)
+
+		slog.Info("request",
+			"request_id", requestIDFromContext(r.Context()),
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", sw.status,
+			"duration", time.Since(start),
+		)
+	})
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusWriter) WriteHeader(code int) {
+	sw.status = code
+	sw.ResponseWriter.WriteHeader(code)
+}
+
+// Flush passes through to the wrapped writer so streaming handlers behind
+// logging can still flush.
+func (sw *statusWriter) Flush() {
+	if f, ok := sw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// recoverPanic turns a panicking handler into a 500 instead of taking down
+// the server, logging the panic value for diagnosis.
+func recoverPanic(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				slog.Error("panic in handler",
+					"request_id", requestIDFromContext(r.Context()),
+					"panic", rec,
+				)
+				http.Error(w, "internal", http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// chain applies middleware in the order given, so chain(h, requestID, logging)
+// runs requestID first and logging second on the way in.
+func chain(h http.Handler, mw ...func(http.Handler) http.Handler) http.Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
+
+/* ============================
+   Auth
+   ============================ */
+
+// apiHandler is an httprouter.Handle that can report a repository error
+// instead of writing the response itself; runHandler below does the
+// translation into status codes, so individual handlers don't repeat it.
+type apiHandler func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) error
+
+// runHandler adapts an apiHandler into an httprouter.Handle, converting
+// gorm.ErrRecordNotFound into a 404 and any other error into a 500.
+func runHandler(h apiHandler) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		err := h(w, r, ps)
+		if err == nil {
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("handler error", "request_id", requestIDFromContext(r.Context()), "error", err)
+		http.Error(w, "internal", http.StatusInternalServerError)
+	}
+}
+
+// requireAuth wraps an apiHandler so it only runs for requests bearing a
+// valid `Authorization: Bearer <token>` header, making the authenticated
+// user available to the handler via userFromRequest.
+func (a *API) requireAuth(next apiHandler) apiHandler {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) error {
+		auth := r.Header.Get("Authorization")
+		raw, ok := strings.CutPrefix(auth, "Bearer ")
+		if !ok || raw == "" {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return nil
+		}
+
+		u, err := a.store.UserByToken(raw)
+		if err != nil {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return nil
+		}
+
+		ctx := context.WithValue(r.Context(), userCtxKey, u)
+		return next(w, r.WithContext(ctx), ps)
+	}
+}
+
+func userFromRequest(r *http.Request) *store.User {
+	u, _ := r.Context().Value(userCtxKey).(*store.User)
+	return u
+}
@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// NewRouter wires every qaapi endpoint behind the standard middleware chain
+// (request id, logging, panic recovery) and serves the OpenAPI spec that
+// describes these endpoints at /swagger. The routes are registered by hand;
+// only the request types in types.gen.go are generated from the spec.
+// specPath is the path to api/openapi.yaml on disk.
+func NewRouter(a *API, specPath string) http.Handler {
+	r := httprouter.New()
+
+	r.HandlerFunc(http.MethodGet, "/swagger", func(w http.ResponseWriter, req *http.Request) {
+		http.ServeFile(w, req, specPath)
+	})
+
+	r.POST("/users", runHandler(a.createUser))
+
+	r.GET("/questions", runHandler(a.listQuestions))
+	r.POST("/questions", runHandler(a.requireAuth(a.createQuestion)))
+	r.GET("/questions/:id", runHandler(a.getQuestion))
+	r.DELETE("/questions/:id", runHandler(a.requireAuth(a.deleteQuestion)))
+	r.POST("/questions/:id/answers", runHandler(a.requireAuth(a.createAnswer)))
+	r.GET("/questions/:id/answers/stream", runHandler(a.streamAnswers))
+
+	r.GET("/answers/:id", runHandler(a.getAnswer))
+	r.DELETE("/answers/:id", runHandler(a.requireAuth(a.deleteAnswer)))
+	r.POST("/answers/:id/vote", runHandler(a.requireAuth(a.voteAnswer)))
+	r.DELETE("/answers/:id/vote", runHandler(a.requireAuth(a.removeVote)))
+
+	return chain(r, requestID, logging, recoverPanic)
+}
@@ -0,0 +1,106 @@
+package transport
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamAnswersDeliversNewAnswer(t *testing.T) {
+	api := newTestAPI(t)
+	ts := httptest.NewServer(NewRouter(api, ""))
+	defer ts.Close()
+
+	token := registerUser(t, ts.URL, "streamer@example.com")
+	q := createQuestion(t, ts.URL, token, "what is go")
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/questions/%d/answers/stream", ts.URL, q.ID), nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET stream: status %d", resp.StatusCode)
+	}
+
+	frames := make(chan string, 1)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, "data: ") {
+				frames <- strings.TrimPrefix(line, "data: ")
+				return
+			}
+		}
+	}()
+
+	// Give the subscriber time to register before the answer is posted, so
+	// the publish isn't racing the subscribe.
+	time.Sleep(50 * time.Millisecond)
+	createAnswer(t, ts.URL, token, q.ID, "42")
+
+	select {
+	case frame := <-frames:
+		if !strings.Contains(frame, "42") {
+			t.Fatalf("SSE frame missing posted answer text: %s", frame)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for SSE frame")
+	}
+}
+
+func TestStreamAnswersUnregistersOnDisconnect(t *testing.T) {
+	api := newTestAPI(t)
+	ts := httptest.NewServer(NewRouter(api, ""))
+	defer ts.Close()
+
+	token := registerUser(t, ts.URL, "disconnector@example.com")
+	q := createQuestion(t, ts.URL, token, "will this leak")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	url := fmt.Sprintf("%s/questions/%d/answers/stream", ts.URL, q.ID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+
+	waitForSubscriberCount(t, api, q.ID, 1, time.Second, "subscriber never registered")
+
+	cancel()
+	resp.Body.Close()
+
+	waitForSubscriberCount(t, api, q.ID, 0, 2*time.Second, "subscriber channel was never unregistered after disconnect")
+}
+
+func waitForSubscriberCount(t *testing.T, api *API, questionID, want int, timeout time.Duration, failMsg string) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		api.hub.mu.Lock()
+		got := len(api.hub.subs[questionID])
+		api.hub.mu.Unlock()
+
+		if got == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s (want %d subscribers, got %d)", failMsg, want, got)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
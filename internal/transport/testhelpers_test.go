@@ -0,0 +1,115 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/axel9119/qaapi/internal/store"
+)
+
+// newTestAPI connects to the Postgres instance named by DATABASE_DSN (or the
+// same default main.go uses) and wipes its tables, mirroring how main wires
+// a *Store. Tests skip, rather than fail, when no database is reachable.
+func newTestAPI(t *testing.T) *API {
+	t.Helper()
+
+	dsn := os.Getenv("DATABASE_DSN")
+	if dsn == "" {
+		dsn = "host=localhost user=qa password=qa_pass dbname=qa_db port=5432 sslmode=disable"
+	}
+
+	db, err := store.Connect(dsn)
+	if err != nil {
+		t.Skipf("postgres not reachable at %q: %v", dsn, err)
+	}
+
+	if err := db.Exec("TRUNCATE votes, answers, questions, users RESTART IDENTITY CASCADE").Error; err != nil {
+		t.Fatalf("truncate tables: %v", err)
+	}
+
+	return New(store.New(db))
+}
+
+// registerUser posts to /users and returns the bearer token it issues.
+func registerUser(t *testing.T, baseURL, email string) string {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"email": email})
+	resp, err := http.Post(baseURL+"/users", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /users: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /users: status %d", resp.StatusCode)
+	}
+
+	var out CreateUserResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decode /users response: %v", err)
+	}
+	return out.Token
+}
+
+// authedRequest issues method/url with body as a Bearer-authenticated
+// request and returns the raw response for the caller to assert on.
+func authedRequest(t *testing.T, method, url, token string, body []byte) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	return resp
+}
+
+// createQuestion posts a question as token's owner and returns it.
+func createQuestion(t *testing.T, baseURL, token, text string) store.Question {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"text": text})
+	resp := authedRequest(t, http.MethodPost, baseURL+"/questions", token, body)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /questions: status %d", resp.StatusCode)
+	}
+
+	var q store.Question
+	if err := json.NewDecoder(resp.Body).Decode(&q); err != nil {
+		t.Fatalf("decode question: %v", err)
+	}
+	return q
+}
+
+// createAnswer posts an answer to questionID as token's owner and returns it.
+func createAnswer(t *testing.T, baseURL, token string, questionID int, text string) store.Answer {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"text": text})
+	url := fmt.Sprintf("%s/questions/%d/answers", baseURL, questionID)
+	resp := authedRequest(t, http.MethodPost, url, token, body)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST %s: status %d", url, resp.StatusCode)
+	}
+
+	var a store.Answer
+	if err := json.NewDecoder(resp.Body).Decode(&a); err != nil {
+		t.Fatalf("decode answer: %v", err)
+	}
+	return a
+}